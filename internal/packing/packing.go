@@ -0,0 +1,73 @@
+// Package packing implements a shelf (skyline) bin packer for laying out
+// fixed-size rects onto one or more fixed-size sheets, as used by the
+// --atlas output mode to pack generated badges into a texture atlas.
+package packing
+
+// Placement is where a rect ended up after Packer.Place: which sheet, and
+// its top-left origin on that sheet.
+type Placement struct {
+	Sheet int
+	X, Y  int
+}
+
+type shelf struct {
+	y, height, cursorX int
+}
+
+type sheetState struct {
+	shelves []*shelf
+	nextY   int
+}
+
+// Packer packs rects of a single fixed size onto SheetW x SheetH sheets
+// using a shelf algorithm: each sheet holds a list of horizontal shelves,
+// each with a running x cursor and a fixed height. A rect is placed on the
+// first shelf whose remaining width and height both fit it; if none fits,
+// a new shelf is opened, and once a sheet runs out of vertical room a new
+// sheet is started.
+type Packer struct {
+	SheetW, SheetH int
+
+	sheets []*sheetState
+}
+
+// NewPacker creates a Packer for sheets of the given size.
+func NewPacker(sheetW, sheetH int) *Packer {
+	return &Packer{
+		SheetW: sheetW,
+		SheetH: sheetH,
+		sheets: []*sheetState{{}},
+	}
+}
+
+// Place assigns a w x h rect to a shelf, opening a new shelf or sheet as
+// needed, and returns where it landed. Not safe for concurrent use.
+func (p *Packer) Place(w, h int) Placement {
+	sheetIdx := len(p.sheets) - 1
+	cur := p.sheets[sheetIdx]
+
+	for _, sh := range cur.shelves {
+		if p.SheetW-sh.cursorX >= w && sh.height >= h {
+			x := sh.cursorX
+			sh.cursorX += w
+			return Placement{Sheet: sheetIdx, X: x, Y: sh.y}
+		}
+	}
+
+	if cur.nextY+h > p.SheetH {
+		cur = &sheetState{}
+		p.sheets = append(p.sheets, cur)
+		sheetIdx++
+	}
+
+	sh := &shelf{y: cur.nextY, height: h, cursorX: w}
+	cur.shelves = append(cur.shelves, sh)
+	cur.nextY += h
+
+	return Placement{Sheet: sheetIdx, X: 0, Y: sh.y}
+}
+
+// SheetCount returns how many sheets have been opened so far.
+func (p *Packer) SheetCount() int {
+	return len(p.sheets)
+}
@@ -1,15 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"embed"
+	"encoding/binary"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"hash/crc32"
 	"image"
 	"image/color"
 	"image/draw"
+	"image/gif"
 	"image/png"
+	"io"
 	"io/fs"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -19,6 +27,9 @@ import (
 	"sync/atomic"
 
 	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+
+	"github.com/Rider21/badge/internal/packing"
 )
 
 //go:embed assets/*
@@ -27,6 +38,15 @@ var assetsFS embed.FS
 const (
 	OutputPath = "images"
 	OutputSize = 300
+
+	// AnimationOutputPath is separate from OutputPath so an animated
+	// badge's filename (e.g. an APNG's "<combo>.png") can never collide
+	// with the matching static badge.
+	AnimationOutputPath = "images/animated"
+
+	// AtlasSheetSize is the width/height of each texture-atlas sheet
+	// produced by --atlas.
+	AtlasSheetSize = 4096
 )
 
 // Data structures
@@ -38,11 +58,97 @@ type BadgeIconData struct {
 }
 
 type RenderJob struct {
+	// Key identifies the combination ("sIdx-bIdx-c1Idx-c2Idx") independent
+	// of Filename, since --content-addressed renames the output to
+	// "<hash>.png" only once the hash is known (after rendering).
+	Key          string
+	Filename     string
+	Palette      color.Palette
+	Symbol       *image.RGBA
+	Border       *image.RGBA
+	Outline      *image.RGBA
+	Quality      ScaleQuality
+	GammaCorrect bool
+}
+
+// ManifestEntry records what a combination last rendered to: the content
+// hash of its post-render RGBA bytes, and the filename it was saved as.
+type ManifestEntry struct {
+	Hash     string `json:"hash"`
+	Filename string `json:"filename"`
+}
+
+// ScaleQuality selects the resampling filter drawLayer uses when scaling a
+// symbol/border/outline layer onto the canvas.
+type ScaleQuality int
+
+const (
+	QualityNearest ScaleQuality = iota
+	QualityBilinear
+	QualityCatmullRom
+)
+
+// parseQuality maps a --quality flag value to a ScaleQuality, falling back
+// to QualityNearest (the historical default) for anything unrecognized.
+func parseQuality(s string) ScaleQuality {
+	switch s {
+	case "bilinear":
+		return QualityBilinear
+	case "catmullrom":
+		return QualityCatmullRom
+	default:
+		return QualityNearest
+	}
+}
+
+// interpolator returns the x/image/draw filter backing this quality level.
+func (q ScaleQuality) interpolator() xdraw.Interpolator {
+	switch q {
+	case QualityBilinear:
+		return xdraw.ApproxBiLinear
+	case QualityCatmullRom:
+		return xdraw.CatmullRom
+	default:
+		return xdraw.NearestNeighbor
+	}
+}
+
+// AnimationKeyframe is one frame of an --animate spec: scale, rotation,
+// tint mix between C1/C2, and the display delay in centiseconds.
+type AnimationKeyframe struct {
+	Scale    float64 `json:"scale"`
+	Rotation float64 `json:"rotation"`
+	TintMix  float64 `json:"tintMix"`
+	DelayCs  int     `json:"delayCs"`
+}
+
+// AnimationSpec is the JSON document accepted by --animate.
+type AnimationSpec struct {
+	Keyframes []AnimationKeyframe `json:"keyframes"`
+	// Format selects the encoder: "gif" (default) or "apng".
+	Format string `json:"format"`
+}
+
+// WriteJob is a finished badge handed off from a render worker to the
+// writer pool, which encodes it to disk and returns Img to palettePool.
+type WriteJob struct {
 	Filename string
-	Palette  color.Palette
-	Symbol   *image.RGBA
-	Border   *image.RGBA
-	Outline  *image.RGBA
+	Img      *image.Paletted
+	// Skip is true when unchanged since the last run, or when a
+	// --content-addressed file of the same name was already queued this
+	// run; the writer still reclaims Img but doesn't touch disk.
+	Skip bool
+}
+
+// AnimationJob is one combination queued for animated rendering.
+type AnimationJob struct {
+	Filename     string
+	Palette      color.Palette
+	Symbol       *image.RGBA
+	Border       *image.RGBA
+	Outline      *image.RGBA
+	Quality      ScaleQuality
+	GammaCorrect bool
 }
 
 var (
@@ -55,10 +161,41 @@ var (
 	rgbaPool    sync.Pool
 	palettePool sync.Pool
 
-	fileCaseMap   map[string]string
-	existingFiles map[string]bool // Cache of existing files
+	fileCaseMap map[string]string
+
+	// Set by --quality / --gamma-correct; applied to every job so
+	// regression tests can compare output across modes.
+	batchQuality      ScaleQuality
+	batchGammaCorrect bool
+
+	// Set by --writers; size of the disk-writer pool. 0 means "same as
+	// numWorkers".
+	batchWriters int
+
+	// Set by --atlas / --atlas-manifest; non-empty atlasOutPath switches
+	// main() into atlas output mode.
+	atlasOutPath      string
+	atlasManifestPath string
+
+	// Set by --content-addressed / --manifest; govern the default batch
+	// mode's manifest-driven, resumable output.
+	contentAddressed bool
+	manifestPath     = filepath.Join(OutputPath, "manifest.json")
+
+	manifestMu sync.Mutex
+	manifest   map[string]ManifestEntry
 )
 
+// AtlasManifestEntry is one badge's location within the --atlas output,
+// keyed by its "sIdx-bIdx-c1Idx-c2Idx" combination.
+type AtlasManifestEntry struct {
+	Sheet int `json:"sheet"`
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	W     int `json:"w"`
+	H     int `json:"h"`
+}
+
 func init() {
 	rgbaPool = sync.Pool{
 		New: func() interface{} {
@@ -80,15 +217,34 @@ func main() {
 		log.Fatalf("Critical error: %v", err)
 	}
 
+	args := parseFlags()
+
 	// Single-run mode
-	if len(os.Args) > 1 {
-		runSingleMode(os.Args[1])
+	if len(args) > 0 {
+		if args[0] == "--animate" {
+			if len(args) < 2 {
+				fmt.Println("Usage: program.exe --animate spec.json")
+				return
+			}
+			runAnimateMode(args[1])
+			return
+		}
+		runSingleMode(args[0])
+		return
+	}
+
+	if atlasOutPath != "" {
+		if atlasManifestPath == "" {
+			fmt.Println("Usage: program.exe --atlas=out.png --atlas-manifest=out.json")
+			return
+		}
+		runAtlasMode(atlasOutPath, atlasManifestPath)
 		return
 	}
 
 	_ = os.MkdirAll(OutputPath, 0755)
 
-	scanOutputDirectory()
+	manifest = loadManifest(manifestPath)
 
 	total := calculateTotal()
 	fmt.Printf("âœ… Resources loaded. Total combinations: %d\n", total)
@@ -97,14 +253,26 @@ func main() {
 	}
 
 	numWorkers := runtime.NumCPU()
+	numWriters := batchWriters
+	if numWriters <= 0 {
+		numWriters = numWorkers
+	}
+
 	jobs := make(chan RenderJob, numWorkers*2)
+	writeJobs := make(chan WriteJob, numWriters*4) // bounded so a slow disk can't pile up unbounded RAM
 	var processedCount int64
-	var wg sync.WaitGroup
+	var renderWg, writeWg sync.WaitGroup
 
-	for range numWorkers {
-		wg.Go(func() {
-			for job := range jobs {
-				processJob(job)
+	// Writers own PNG encoding and return each Img to palettePool (render
+	// workers check it out in renderJob), so rendering doesn't wait on each
+	// encode; a render worker only blocks once writeJobs is full.
+	for range numWriters {
+		writeWg.Go(func() {
+			for wj := range writeJobs {
+				if !wj.Skip {
+					writePNG(wj.Filename, wj.Img)
+				}
+				palettePool.Put(wj.Img)
 				curr := atomic.AddInt64(&processedCount, 1)
 				if curr%100 == 0 || curr == int64(total) {
 					printProgress(curr, int64(total))
@@ -113,6 +281,14 @@ func main() {
 		})
 	}
 
+	for range numWorkers {
+		renderWg.Go(func() {
+			for job := range jobs {
+				processJob(job, writeJobs)
+			}
+		})
+	}
+
 	// Job generator
 	go func() {
 		for _, c1Idx := range layer0ColorIndices {
@@ -137,20 +313,17 @@ func main() {
 						if border.Layer != 1 {
 							continue
 						}
-						fName := fmt.Sprintf("%d-%d-%d-%d.png", sIdx, bIdx, c1Idx, c2Idx)
-
-						// Check map (avoid os.Stat calls)
-						if existingFiles[fName] {
-							atomic.AddInt64(&processedCount, 1)
-							continue
-						}
+						key := fmt.Sprintf("%d-%d-%d-%d", sIdx, bIdx, c1Idx, c2Idx)
 
 						jobs <- RenderJob{
-							Filename: fName,
-							Palette:  sharedPalette,
-							Symbol:   symbol.OriginalIcon,
-							Border:   border.OriginalIcon,
-							Outline:  border.OriginalOutline,
+							Key:          key,
+							Filename:     key + ".png",
+							Palette:      sharedPalette,
+							Symbol:       symbol.OriginalIcon,
+							Border:       border.OriginalIcon,
+							Outline:      border.OriginalOutline,
+							Quality:      batchQuality,
+							GammaCorrect: batchGammaCorrect,
 						}
 					}
 				}
@@ -159,11 +332,55 @@ func main() {
 		close(jobs)
 	}()
 
-	wg.Wait()
+	renderWg.Wait()
+	close(writeJobs)
+	writeWg.Wait()
+
+	if err := saveManifestAtomic(manifestPath, manifest); err != nil {
+		log.Printf("Warning: failed to save manifest: %v", err)
+	}
 	fmt.Printf("\nâœ¨ Generation complete! Saved to: %s\n", OutputPath)
 }
 
-func processJob(j RenderJob) {
+// writtenOutputs guards against two jobs whose content hashes to the same
+// --content-addressed filename (e.g. both outlines nil) both writing it.
+var writtenOutputs sync.Map
+
+func processJob(j RenderJob, writeJobs chan<- WriteJob) {
+	palImg, hash := renderJob(j)
+	hashHex := fmt.Sprintf("%x", hash)
+
+	outputName := j.Filename
+	if contentAddressed {
+		outputName = hashHex + ".png"
+	}
+
+	manifestMu.Lock()
+	prev, known := manifest[j.Key]
+	skip := known && prev.Hash == hashHex && prev.Filename == outputName
+	manifest[j.Key] = ManifestEntry{Hash: hashHex, Filename: outputName}
+	manifestMu.Unlock()
+
+	if skip {
+		// Hash/filename match the manifest, but the file itself may have
+		// been deleted or never written (e.g. a previous run crashed
+		// mid-write) -- only actually skip if it's still on disk.
+		if _, err := os.Stat(filepath.Join(OutputPath, outputName)); err != nil {
+			skip = false
+		}
+	}
+	if !skip && contentAddressed {
+		if _, dup := writtenOutputs.LoadOrStore(outputName, struct{}{}); dup {
+			skip = true
+		}
+	}
+
+	writeJobs <- WriteJob{Filename: outputName, Img: palImg, Skip: skip}
+}
+
+// renderJob composes j's layers and quantizes against j.Palette, returning
+// a pooled *image.Paletted plus the content hash of the pre-quantization RGBA.
+func renderJob(j RenderJob) (*image.Paletted, [sha256.Size]byte) {
 	// Get two buffers from the pool: one for the final image, one for the mask (scratch)
 	dst := rgbaPool.Get().(*image.RGBA)
 	scratch := rgbaPool.Get().(*image.RGBA)
@@ -171,30 +388,40 @@ func processJob(j RenderJob) {
 	defer rgbaPool.Put(dst)
 	defer rgbaPool.Put(scratch)
 
-	// Clear dst
-	draw.Draw(dst, dst.Bounds(), image.Transparent, image.Point{}, draw.Src)
-
 	// Colors are taken from the palette (Palette[1]=C1, Palette[2]=C2)
 	c1 := j.Palette[1]
 	c2 := j.Palette[2]
 
+	composeFrame(dst, scratch, j.Border, j.Symbol, j.Outline, c1, c2, 1.0, 0, j.Quality, j.GammaCorrect)
+	hash := sha256.Sum256(dst.Pix)
+
+	palImg := palettePool.Get().(*image.Paletted)
+	palImg.Palette = j.Palette
+	draw.Draw(palImg, palImg.Bounds(), dst, image.Point{}, draw.Src)
+	return palImg, hash
+}
+
+// composeFrame draws the border/symbol/outline stack onto dst, scaled and
+// rotated, using c1/c2 as tints. Shared by the static path and --animate.
+func composeFrame(dst, scratch, border, symbol, outline *image.RGBA, c1, c2 color.Color, scaleMul, rotationDeg float64, quality ScaleQuality, gammaCorrect bool) {
+	// Clear dst
+	draw.Draw(dst, dst.Bounds(), image.Transparent, image.Point{}, draw.Src)
+
 	// Layer 1: Border Base (Scale 1.0, Tint C2)
-	if j.Border != nil {
-		drawLayer(dst, scratch, j.Border, c2, 1.0)
+	if border != nil {
+		drawLayer(dst, scratch, border, c2, 1.0*scaleMul, rotationDeg, quality, gammaCorrect)
 	}
 	// Layer 2: Symbol (Scale 0.7, Tint C1)
-	if j.Symbol != nil {
-		drawLayer(dst, scratch, j.Symbol, c1, 0.7)
+	if symbol != nil {
+		drawLayer(dst, scratch, symbol, c1, 0.7*scaleMul, rotationDeg, quality, gammaCorrect)
 	}
 	// Layer 3: Outline (Scale 1.0, Tint C1)
-	if j.Outline != nil {
-		drawLayer(dst, scratch, j.Outline, c1, 1.0)
+	if outline != nil {
+		drawLayer(dst, scratch, outline, c1, 1.0*scaleMul, rotationDeg, quality, gammaCorrect)
 	}
-
-	savePNG(j.Filename, dst, j.Palette)
 }
 
-func drawLayer(dst, scratch, src *image.RGBA, tint color.Color, scale float64) {
+func drawLayer(dst, scratch, src *image.RGBA, tint color.Color, scale, rotationDeg float64, quality ScaleQuality, gammaCorrect bool) {
 	sb := src.Bounds()
 	w, h := int(float64(sb.Dx())*scale), int(float64(sb.Dy())*scale)
 	if w <= 0 || h <= 0 {
@@ -207,20 +434,107 @@ func drawLayer(dst, scratch, src *image.RGBA, tint color.Color, scale float64) {
 	// Clear scratch (important because it comes from the pool)
 	draw.Draw(scratch, rect, image.Transparent, image.Point{}, draw.Src)
 
-	// Scale: source -> scratch
-	xdraw.NearestNeighbor.Scale(scratch, rect, src, sb, xdraw.Over, nil)
+	interp := quality.interpolator()
+	if rotationDeg == 0 {
+		// Scale: source -> scratch
+		interp.Scale(scratch, rect, src, sb, xdraw.Over, nil)
+	} else {
+		interp.Transform(scratch, rotationMatrix(rect, sb, rotationDeg), src, sb, xdraw.Over, nil)
+	}
 
 	// Apply tint: draw tint onto dst using scratch as mask
-	draw.DrawMask(dst, rect, image.NewUniform(tint), image.Point{}, scratch, rect.Min, draw.Over)
+	if gammaCorrect {
+		compositeGammaCorrect(dst, rect, tint, scratch)
+	} else {
+		draw.DrawMask(dst, rect, image.NewUniform(tint), image.Point{}, scratch, rect.Min, draw.Over)
+	}
 }
 
-func savePNG(filename string, img *image.RGBA, pal color.Palette) {
-	palImg := palettePool.Get().(*image.Paletted)
-	defer palettePool.Put(palImg)
+// compositeGammaCorrect composites tint over dst using mask's alpha as
+// coverage, blending in linear light to avoid sRGB-space darkened edges.
+func compositeGammaCorrect(dst *image.RGBA, rect image.Rectangle, tint color.Color, mask *image.RGBA) {
+	tr, tg, tb, _ := tint.RGBA()
+	tLinR := srgbToLinear(uint8(tr >> 8))
+	tLinG := srgbToLinear(uint8(tg >> 8))
+	tLinB := srgbToLinear(uint8(tb >> 8))
 
-	palImg.Palette = pal
-	draw.Draw(palImg, palImg.Bounds(), img, image.Point{}, draw.Src)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			_, _, _, ma := mask.At(x, y).RGBA()
+			coverage := float64(ma) / 0xffff
+			if coverage == 0 {
+				continue
+			}
+
+			// dst.At returns alpha-premultiplied channels; un-premultiply
+			// before linearizing or partially-covered pixels get double
+			// darkened by dAlpha below.
+			dr, dg, db, da := dst.At(x, y).RGBA()
+			var dLinR, dLinG, dLinB float64
+			if da > 0 {
+				dLinR = srgbToLinear(uint8(dr * 255 / da))
+				dLinG = srgbToLinear(uint8(dg * 255 / da))
+				dLinB = srgbToLinear(uint8(db * 255 / da))
+			}
+			dAlpha := float64(da) / 0xffff
 
+			outAlpha := coverage + dAlpha*(1-coverage)
+			var outR, outG, outB float64
+			if outAlpha > 0 {
+				outR = (tLinR*coverage + dLinR*dAlpha*(1-coverage)) / outAlpha
+				outG = (tLinG*coverage + dLinG*dAlpha*(1-coverage)) / outAlpha
+				outB = (tLinB*coverage + dLinB*dAlpha*(1-coverage)) / outAlpha
+			}
+
+			// Re-premultiply before storing: color.RGBA's fields are
+			// alpha-premultiplied.
+			sr, sg, sb := float64(linearToSRGB(outR)), float64(linearToSRGB(outG)), float64(linearToSRGB(outB))
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(sr*outAlpha + 0.5),
+				G: uint8(sg*outAlpha + 0.5),
+				B: uint8(sb*outAlpha + 0.5),
+				A: uint8(outAlpha*255 + 0.5),
+			})
+		}
+	}
+}
+
+func srgbToLinear(c uint8) float64 {
+	return math.Pow(float64(c)/255, 2.2)
+}
+
+func linearToSRGB(c float64) uint8 {
+	if c < 0 {
+		c = 0
+	}
+	if c > 1 {
+		c = 1
+	}
+	return uint8(math.Pow(c, 1/2.2)*255 + 0.5)
+}
+
+// rotationMatrix builds the affine transform that scales src into rect and
+// then rotates it by rotationDeg degrees around rect's center.
+func rotationMatrix(rect image.Rectangle, src image.Rectangle, rotationDeg float64) f64.Aff3 {
+	sx := float64(rect.Dx()) / float64(src.Dx())
+	sy := float64(rect.Dy()) / float64(src.Dy())
+	cx, cy := float64(rect.Min.X+rect.Max.X)/2, float64(rect.Min.Y+rect.Max.Y)/2
+
+	theta := rotationDeg * math.Pi / 180
+	sin, cos := math.Sin(theta), math.Cos(theta)
+
+	// Scale src into rect, centered at the origin, then rotate, then
+	// translate back to rect's center.
+	return f64.Aff3{
+		cos * sx, -sin * sy, cx - (cos*sx*float64(src.Dx())-sin*sy*float64(src.Dy()))/2 - sx*float64(src.Min.X)*cos + sy*float64(src.Min.Y)*sin,
+		sin * sx, cos * sy, cy - (sin*sx*float64(src.Dx())+cos*sy*float64(src.Dy()))/2 - sx*float64(src.Min.X)*sin - sy*float64(src.Min.Y)*cos,
+	}
+}
+
+// writePNG encodes an already-quantized *image.Paletted to disk. It does
+// not touch palettePool — the caller (a writer goroutine, or runSingleMode)
+// owns checking the image back in once this returns.
+func writePNG(filename string, palImg *image.Paletted) {
 	// Determine output path. In single-run mode we may use "badge.png" as a simple filename.
 	fullPath := filepath.Join(OutputPath, filename)
 	if filename == "badge.png" {
@@ -237,19 +551,410 @@ func savePNG(filename string, img *image.RGBA, pal color.Palette) {
 	_ = enc.Encode(f, palImg)
 }
 
+// --- Animated badges (--animate) ---
+
+func loadAnimationSpec(path string) (*AnimationSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec AnimationSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if len(spec.Keyframes) == 0 {
+		return nil, fmt.Errorf("animation spec has no keyframes")
+	}
+	if spec.Format == "" {
+		spec.Format = "gif"
+	}
+	return &spec, nil
+}
+
+func runAnimateMode(specPath string) {
+	spec, err := loadAnimationSpec(specPath)
+	if err != nil {
+		log.Fatalf("Critical error: %v", err)
+	}
+
+	_ = os.MkdirAll(AnimationOutputPath, 0755)
+	existingAnim := scanDirectory(AnimationOutputPath, animationExt(spec.Format))
+
+	total := calculateTotal()
+	fmt.Printf("✅ Resources loaded. Total combinations: %d\n", total)
+	if total == 0 {
+		return
+	}
+
+	numWorkers := runtime.NumCPU()
+	jobs := make(chan AnimationJob, numWorkers*2)
+	var processedCount int64
+	var wg sync.WaitGroup
+
+	for range numWorkers {
+		wg.Go(func() {
+			for job := range jobs {
+				processAnimationJob(job, spec)
+				curr := atomic.AddInt64(&processedCount, 1)
+				if curr%100 == 0 || curr == int64(total) {
+					printProgress(curr, int64(total))
+				}
+			}
+		})
+	}
+
+	// Job generator
+	go func() {
+		for _, c1Idx := range layer0ColorIndices {
+			c1 := getColor(c1Idx)
+
+			for _, c2Idx := range layer1ColorIndices {
+				c2 := getColor(c2Idx)
+				sharedPalette := animationPalette(c1, c2)
+
+				for sIdx, symbol := range badgeIcons {
+					if symbol.Layer != 0 {
+						continue
+					}
+					for bIdx, border := range badgeIcons {
+						if border.Layer != 1 {
+							continue
+						}
+						fName := fmt.Sprintf("%d-%d-%d-%d.%s", sIdx, bIdx, c1Idx, c2Idx, animationExt(spec.Format))
+
+						if existingAnim[fName] {
+							atomic.AddInt64(&processedCount, 1)
+							continue
+						}
+
+						jobs <- AnimationJob{
+							Filename:     fName,
+							Palette:      sharedPalette,
+							Symbol:       symbol.OriginalIcon,
+							Border:       border.OriginalIcon,
+							Outline:      border.OriginalOutline,
+							Quality:      batchQuality,
+							GammaCorrect: batchGammaCorrect,
+						}
+					}
+				}
+			}
+		}
+		close(jobs)
+	}()
+
+	wg.Wait()
+	fmt.Printf("\n✨ Animated generation complete! Saved to: %s\n", AnimationOutputPath)
+}
+
+// animationPalette builds the shared palette an animation's frames are all
+// quantized against, with interpolated steps between C1 and C2.
+func animationPalette(c1, c2 color.RGBA) color.Palette {
+	const steps = 8
+	pal := color.Palette{color.RGBA{0, 0, 0, 0}, c1, c2}
+	for i := 1; i < steps; i++ {
+		pal = append(pal, lerpColor(c1, c2, float64(i)/float64(steps)))
+	}
+	return pal
+}
+
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(a.R) + (float64(b.R)-float64(a.R))*t),
+		G: uint8(float64(a.G) + (float64(b.G)-float64(a.G))*t),
+		B: uint8(float64(a.B) + (float64(b.B)-float64(a.B))*t),
+		A: 255,
+	}
+}
+
+func processAnimationJob(j AnimationJob, spec *AnimationSpec) {
+	dst := rgbaPool.Get().(*image.RGBA)
+	scratch := rgbaPool.Get().(*image.RGBA)
+	defer rgbaPool.Put(dst)
+	defer rgbaPool.Put(scratch)
+
+	c1, _ := j.Palette[1].(color.RGBA)
+	c2, _ := j.Palette[2].(color.RGBA)
+
+	frames := make([]*image.Paletted, 0, len(spec.Keyframes))
+	delays := make([]int, 0, len(spec.Keyframes))
+
+	for _, kf := range spec.Keyframes {
+		frameC1 := lerpColor(c1, c2, kf.TintMix)
+		frameC2 := lerpColor(c2, c1, kf.TintMix)
+		composeFrame(dst, scratch, j.Border, j.Symbol, j.Outline, frameC1, frameC2, kf.Scale, kf.Rotation, j.Quality, j.GammaCorrect)
+
+		palImg := palettePool.Get().(*image.Paletted)
+		palImg.Palette = j.Palette
+		draw.Draw(palImg, palImg.Bounds(), dst, image.Point{}, draw.Src)
+
+		frames = append(frames, palImg)
+		delays = append(delays, kf.DelayCs)
+	}
+	defer func() {
+		for _, f := range frames {
+			palettePool.Put(f)
+		}
+	}()
+
+	if spec.Format == "apng" {
+		saveAPNG(j.Filename, frames, delays)
+	} else {
+		saveGIF(j.Filename, frames, delays)
+	}
+}
+
+func animationExt(format string) string {
+	if format == "apng" {
+		return "png"
+	}
+	return "gif"
+}
+
+func saveGIF(filename string, frames []*image.Paletted, delaysCs []int) {
+	fullPath := filepath.Join(AnimationOutputPath, filename)
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	g := &gif.GIF{Image: frames, Delay: delaysCs, LoopCount: 0}
+	_ = gif.EncodeAll(f, g)
+}
+
+func saveAPNG(filename string, frames []*image.Paletted, delaysCs []int) {
+	fullPath := filepath.Join(AnimationOutputPath, filename)
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	_ = encodeAPNG(f, frames, delaysCs)
+}
+
+// --- APNG encoding ---
+//
+// encoding/png has no animation support, so frames are PNG-encoded
+// individually and repackaged into the APNG chunk sequence by hand.
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+func encodeAPNG(w io.Writer, frames []*image.Paletted, delaysCs []int) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("apng: no frames to encode")
+	}
+
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+
+	var first bytes.Buffer
+	if err := enc.Encode(&first, frames[0]); err != nil {
+		return err
+	}
+	ihdr, plte, trns, idat, err := splitPNGChunks(first.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "IHDR", ihdr); err != nil {
+		return err
+	}
+	if plte != nil {
+		if err := writeChunk(w, "PLTE", plte); err != nil {
+			return err
+		}
+	}
+	if trns != nil {
+		if err := writeChunk(w, "tRNS", trns); err != nil {
+			return err
+		}
+	}
+	if err := writeChunk(w, "acTL", acTLChunk(len(frames))); err != nil {
+		return err
+	}
+
+	var seq uint32
+	if err := writeChunk(w, "fcTL", fcTLChunk(seq, frames[0].Bounds(), delaysCs[0])); err != nil {
+		return err
+	}
+	seq++
+	if err := writeChunk(w, "IDAT", idat); err != nil {
+		return err
+	}
+
+	for i := 1; i < len(frames); i++ {
+		var buf bytes.Buffer
+		if err := enc.Encode(&buf, frames[i]); err != nil {
+			return err
+		}
+		_, _, _, idat, err := splitPNGChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		if err := writeChunk(w, "fcTL", fcTLChunk(seq, frames[i].Bounds(), delaysCs[i])); err != nil {
+			return err
+		}
+		seq++
+		if err := writeChunk(w, "fdAT", fdATChunk(seq, idat)); err != nil {
+			return err
+		}
+		seq++
+	}
+
+	return writeChunk(w, "IEND", nil)
+}
+
+// splitPNGChunks pulls the IHDR, PLTE, tRNS (the stdlib always encodes
+// image.Paletted as indexed color, so PLTE is required and tRNS follows
+// whenever the palette has a transparent entry) and concatenated IDAT
+// payloads out of a PNG byte stream produced by the stdlib encoder.
+func splitPNGChunks(pngBytes []byte) (ihdr, plte, trns, idat []byte, err error) {
+	if len(pngBytes) < 8 || !bytes.Equal(pngBytes[:8], pngSignature) {
+		return nil, nil, nil, nil, fmt.Errorf("apng: not a PNG stream")
+	}
+	pos := 8
+	for pos+8 <= len(pngBytes) {
+		length := int(binary.BigEndian.Uint32(pngBytes[pos : pos+4]))
+		typ := string(pngBytes[pos+4 : pos+8])
+		data := pngBytes[pos+8 : pos+8+length]
+		switch typ {
+		case "IHDR":
+			ihdr = data
+		case "PLTE":
+			plte = data
+		case "tRNS":
+			trns = data
+		case "IDAT":
+			idat = append(idat, data...)
+		}
+		pos += 8 + length + 4 // length + type + data + CRC
+	}
+	if ihdr == nil || idat == nil {
+		return nil, nil, nil, nil, fmt.Errorf("apng: source PNG missing IHDR/IDAT")
+	}
+	return ihdr, plte, trns, idat, nil
+}
+
+func writeChunk(w io.Writer, chunkType string, data []byte) error {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(data)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	typeAndData := append([]byte(chunkType), data...)
+	if _, err := w.Write(typeAndData); err != nil {
+		return err
+	}
+	var crcBuf [4]byte
+	binary.BigEndian.PutUint32(crcBuf[:], crc32.ChecksumIEEE(typeAndData))
+	_, err := w.Write(crcBuf[:])
+	return err
+}
+
+func acTLChunk(numFrames int) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(numFrames))
+	binary.BigEndian.PutUint32(buf[4:8], 0) // num_plays: 0 = loop forever
+	return buf
+}
+
+func fcTLChunk(seq uint32, bounds image.Rectangle, delayCs int) []byte {
+	buf := make([]byte, 26)
+	binary.BigEndian.PutUint32(buf[0:4], seq)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(bounds.Dx()))
+	binary.BigEndian.PutUint32(buf[8:12], uint32(bounds.Dy()))
+	binary.BigEndian.PutUint32(buf[12:16], 0) // x_offset
+	binary.BigEndian.PutUint32(buf[16:20], 0) // y_offset
+	binary.BigEndian.PutUint16(buf[20:22], uint16(delayCs))
+	binary.BigEndian.PutUint16(buf[22:24], 100) // delay_den: delayCs is already in centiseconds
+	buf[24] = 0                                 // dispose_op: none
+	buf[25] = 0                                 // blend_op: source
+	return buf
+}
+
+func fdATChunk(seq uint32, data []byte) []byte {
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf[0:4], seq)
+	copy(buf[4:], data)
+	return buf
+}
+
 // --- Helpers ---
 
-func scanOutputDirectory() {
-	existingFiles = make(map[string]bool)
-	entries, err := os.ReadDir(OutputPath)
+// parseFlags strips recognized --foo=bar/--foo flags out of os.Args, setting
+// the package-level batch*, atlas*, contentAddressed and manifestPath vars,
+// and returns the remaining positional args.
+func parseFlags() []string {
+	var rest []string
+	for _, arg := range os.Args[1:] {
+		switch {
+		case strings.HasPrefix(arg, "--quality="):
+			batchQuality = parseQuality(strings.TrimPrefix(arg, "--quality="))
+		case arg == "--gamma-correct":
+			batchGammaCorrect = true
+		case strings.HasPrefix(arg, "--writers="):
+			batchWriters, _ = strconv.Atoi(strings.TrimPrefix(arg, "--writers="))
+		case strings.HasPrefix(arg, "--atlas-manifest="):
+			atlasManifestPath = strings.TrimPrefix(arg, "--atlas-manifest=")
+		case strings.HasPrefix(arg, "--atlas="):
+			atlasOutPath = strings.TrimPrefix(arg, "--atlas=")
+		case arg == "--content-addressed":
+			contentAddressed = true
+		case strings.HasPrefix(arg, "--manifest="):
+			manifestPath = strings.TrimPrefix(arg, "--manifest=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest
+}
+
+// scanDirectory returns the set of filenames directly inside dir ending in
+// "."+ext, used by runAnimateMode to avoid re-rendering what it already
+// wrote to its own output directory.
+func scanDirectory(dir, ext string) map[string]bool {
+	existing := make(map[string]bool)
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return // Directory may not exist; that's OK
+		return existing // Directory may not exist; that's OK
 	}
 	for _, e := range entries {
-		if !e.IsDir() && strings.HasSuffix(e.Name(), ".png") {
-			existingFiles[e.Name()] = true
+		if !e.IsDir() && strings.HasSuffix(e.Name(), "."+ext) {
+			existing[e.Name()] = true
 		}
 	}
+	return existing
+}
+
+// loadManifest reads the manifest.json produced by a previous run. A
+// missing or unreadable manifest just means a full run (every combination
+// is treated as new).
+func loadManifest(path string) map[string]ManifestEntry {
+	m := make(map[string]ManifestEntry)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m
+	}
+	_ = json.Unmarshal(data, &m)
+	return m
+}
+
+// saveManifestAtomic writes the manifest via a temp file + rename so a run
+// that's interrupted mid-write can't leave a corrupt manifest.json behind.
+func saveManifestAtomic(path string, m map[string]ManifestEntry) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
 }
 
 func runSingleMode(arg string) {
@@ -284,15 +989,19 @@ func runSingleMode(arg string) {
 	}
 
 	job := RenderJob{
-		Filename: "badge.png",
-		Palette:  pal,
-		Symbol:   sym.OriginalIcon,
-		Border:   border.OriginalIcon,
-		Outline:  border.OriginalOutline,
+		Filename:     "badge.png",
+		Palette:      pal,
+		Symbol:       sym.OriginalIcon,
+		Border:       border.OriginalIcon,
+		Outline:      border.OriginalOutline,
+		Quality:      batchQuality,
+		GammaCorrect: batchGammaCorrect,
 	}
 
-	// Run directly
-	processJob(job)
+	// Run directly (no writer pool for a single badge)
+	palImg, _ := renderJob(job)
+	writePNG(job.Filename, palImg)
+	palettePool.Put(palImg)
 	fmt.Println("âœ… Generated badge.png")
 }
 
@@ -395,3 +1104,147 @@ func printProgress(curr, total int64) {
 	percent := float64(curr) / float64(total) * 100
 	fmt.Printf("\033[2K\r[Progress] %.2f%% (%d/%d)", percent, curr, total)
 }
+
+// --- Texture atlas (--atlas) ---
+
+// runAtlasMode renders every combination like the default batch mode, but
+// instead of writing one PNG per combination it blits each onto a shared
+// RGBA sheet packed by internal/packing, then writes the sheet(s) plus a
+// JSON manifest mapping each combination to its {sheet, x, y, w, h}.
+func runAtlasMode(outPath, manifestPath string) {
+	total := calculateTotal()
+	fmt.Printf("âœ… Resources loaded. Total combinations: %d\n", total)
+	if total == 0 {
+		return
+	}
+
+	numWorkers := runtime.NumCPU()
+	jobs := make(chan RenderJob, numWorkers*2)
+	var processedCount int64
+	var wg sync.WaitGroup
+
+	packer := packing.NewPacker(AtlasSheetSize, AtlasSheetSize)
+	manifest := make(map[string]AtlasManifestEntry)
+	var sheets []*image.RGBA
+	var atlasMu sync.Mutex
+
+	for range numWorkers {
+		wg.Go(func() {
+			for job := range jobs {
+				processAtlasJob(job, packer, manifest, &sheets, &atlasMu)
+				curr := atomic.AddInt64(&processedCount, 1)
+				if curr%100 == 0 || curr == int64(total) {
+					printProgress(curr, int64(total))
+				}
+			}
+		})
+	}
+
+	// Job generator (mirrors main's, keyed by the bare combination string
+	// rather than a ".png" filename since there's no per-badge file here)
+	go func() {
+		for _, c1Idx := range layer0ColorIndices {
+			c1 := getColor(c1Idx)
+
+			for _, c2Idx := range layer1ColorIndices {
+				c2 := getColor(c2Idx)
+				sharedPalette := color.Palette{
+					color.RGBA{0, 0, 0, 0},
+					c1,
+					c2,
+				}
+
+				for sIdx, symbol := range badgeIcons {
+					if symbol.Layer != 0 {
+						continue
+					}
+					for bIdx, border := range badgeIcons {
+						if border.Layer != 1 {
+							continue
+						}
+						jobs <- RenderJob{
+							Filename:     fmt.Sprintf("%d-%d-%d-%d", sIdx, bIdx, c1Idx, c2Idx),
+							Palette:      sharedPalette,
+							Symbol:       symbol.OriginalIcon,
+							Border:       border.OriginalIcon,
+							Outline:      border.OriginalOutline,
+							Quality:      batchQuality,
+							GammaCorrect: batchGammaCorrect,
+						}
+					}
+				}
+			}
+		}
+		close(jobs)
+	}()
+
+	wg.Wait()
+
+	if err := saveAtlas(outPath, manifestPath, sheets, manifest); err != nil {
+		log.Fatalf("Critical error: %v", err)
+	}
+	fmt.Printf("\nâœ¨ Atlas generation complete! %d sheet(s), manifest at %s\n", len(sheets), manifestPath)
+}
+
+// processAtlasJob renders j, then places and blits it onto the shared
+// atlas sheets under atlasMu (both the packer and the sheet slice are
+// shared mutable state, so the whole place+blit happens while held).
+func processAtlasJob(j RenderJob, packer *packing.Packer, manifest map[string]AtlasManifestEntry, sheets *[]*image.RGBA, atlasMu *sync.Mutex) {
+	dst := rgbaPool.Get().(*image.RGBA)
+	scratch := rgbaPool.Get().(*image.RGBA)
+	defer rgbaPool.Put(dst)
+	defer rgbaPool.Put(scratch)
+
+	c1 := j.Palette[1]
+	c2 := j.Palette[2]
+	composeFrame(dst, scratch, j.Border, j.Symbol, j.Outline, c1, c2, 1.0, 0, j.Quality, j.GammaCorrect)
+
+	atlasMu.Lock()
+	defer atlasMu.Unlock()
+
+	placement := packer.Place(OutputSize, OutputSize)
+	for placement.Sheet >= len(*sheets) {
+		*sheets = append(*sheets, image.NewRGBA(image.Rect(0, 0, AtlasSheetSize, AtlasSheetSize)))
+	}
+
+	destRect := image.Rect(placement.X, placement.Y, placement.X+OutputSize, placement.Y+OutputSize)
+	draw.Draw((*sheets)[placement.Sheet], destRect, dst, image.Point{}, draw.Src)
+
+	manifest[j.Filename] = AtlasManifestEntry{
+		Sheet: placement.Sheet,
+		X:     placement.X,
+		Y:     placement.Y,
+		W:     OutputSize,
+		H:     OutputSize,
+	}
+}
+
+// saveAtlas writes each packed sheet as a PNG (numbered when there's more
+// than one) and the manifest as indented JSON.
+func saveAtlas(outPath, manifestPath string, sheets []*image.RGBA, manifest map[string]AtlasManifestEntry) error {
+	ext := filepath.Ext(outPath)
+	base := strings.TrimSuffix(outPath, ext)
+
+	for i, sheet := range sheets {
+		path := outPath
+		if len(sheets) > 1 {
+			path = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = png.Encode(f, sheet)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, data, 0644)
+}